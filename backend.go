@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+)
+
+// resolveBackend builds the Backend to search with, and the endpoint config
+// behind it (nil with no --config). With no --config it's a plain breach.vip
+// Client pointed at flagURL. With --config, the named endpoint's settings
+// are used, except flagURL overrides the endpoint's URL when the user
+// passed --url explicitly (flags win over config).
+func resolveBackend(configPath, backendName, flagURL string, urlFlagSet bool) (breachvip.Backend, *breachvip.EndpointConfig, error) {
+	if configPath == "" {
+		return breachvip.NewClient(breachvip.WithBaseURL(flagURL)), nil, nil
+	}
+
+	cfg, err := breachvip.LoadConfig(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoint, ok := cfg.Endpoints[backendName]
+	if !ok {
+		return nil, nil, fmt.Errorf("no endpoint named %q in %s", backendName, configPath)
+	}
+	if urlFlagSet {
+		endpoint.URL = flagURL
+	}
+	if endpoint.URL == "" {
+		endpoint.URL = breachvip.DefaultBaseURL
+	}
+	backend, err := breachvip.NewBackend(endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return backend, &endpoint, nil
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+	"github.com/Mortimus/BreachLookup/pkg/breachvip/output"
+)
+
+// formatExtensions maps an output format to the file extension its shard
+// should use.
+var formatExtensions = map[string]string{
+	"json":   ".json",
+	"ndjson": ".ndjson",
+	"csv":    ".csv",
+	"sqlite": ".sqlite",
+}
+
+// bulkTerm is one line of --input-file: a search term and an optional
+// per-term field override (from the CSV "term,field" form).
+type bulkTerm struct {
+	Term  string
+	Field string
+}
+
+// bulkTermResult is one term's entry in summary.json.
+type bulkTermResult struct {
+	Term      string `json:"term"`
+	Total     int    `json:"total"`
+	Emails    int    `json:"emails"`
+	Passwords int    `json:"passwords"`
+	Elapsed   string `json:"elapsed"`
+	Error     string `json:"error,omitempty"`
+}
+
+// bulkSummary is the aggregate report written to out/summary.json.
+type bulkSummary struct {
+	Terms   []bulkTermResult `json:"terms"`
+	Elapsed string           `json:"elapsed"`
+}
+
+var sanitizeTermPattern = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// sanitizeTerm turns a search term into a safe file name component.
+func sanitizeTerm(term string) string {
+	s := strings.Trim(sanitizeTermPattern.ReplaceAllString(term, "_"), "_")
+	if s == "" {
+		s = "term"
+	}
+	return s
+}
+
+// readBulkInput reads --input-file: one term per line, or "term,field" CSV
+// rows when a line contains a comma.
+func readBulkInput(path string) ([]bulkTerm, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var terms []bulkTerm
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.Contains(line, ",") {
+			record, err := csv.NewReader(strings.NewReader(line)).Read()
+			if err == nil && len(record) >= 2 {
+				terms = append(terms, bulkTerm{Term: strings.TrimSpace(record[0]), Field: strings.TrimSpace(record[1])})
+				continue
+			}
+		}
+		terms = append(terms, bulkTerm{Term: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+// runBulkMode reads terms from inputFile and processes them through a
+// worker pool of size concurrency, each term sharing limiter and retrying up
+// to retries times. Every term gets its own output shard plus emails_/
+// passwords_ sidecars under outDir, and an aggregate summary.json records
+// per-term totals, elapsed time, and errors.
+func runBulkMode(backend breachvip.Backend, inputFile string, baseReq breachvip.SearchRequest, outDir string, concurrency int, limiter *breachvip.RateLimiter, retries int, dedupeKey string, maxPages int, format string) error {
+	terms, err := readBulkInput(inputFile)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", inputFile, err)
+	}
+	if len(terms) == 0 {
+		return fmt.Errorf("no terms found in %s", inputFile)
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	start := time.Now()
+	results := make([]bulkTermResult, len(terms))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = processBulkTerm(backend, terms[i], baseReq, outDir, limiter, retries, dedupeKey, maxPages, format)
+				fmt.Printf("[%d/%d] %s: %d results\n", i+1, len(terms), terms[i].Term, results[i].Total)
+			}
+		}()
+	}
+	for i := range terms {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	summary := bulkSummary{Terms: results, Elapsed: time.Since(start).String()}
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling summary: %w", err)
+	}
+	summaryPath := filepath.Join(outDir, "summary.json")
+	if err := os.WriteFile(summaryPath, data, 0644); err != nil {
+		return fmt.Errorf("writing summary: %w", err)
+	}
+	fmt.Printf("Bulk run complete: %d terms, summary saved to %s\n", len(terms), summaryPath)
+	return nil
+}
+
+// processBulkTerm runs one term's search to completion and writes its shard
+// and sidecar files.
+func processBulkTerm(backend breachvip.Backend, term bulkTerm, baseReq breachvip.SearchRequest, outDir string, limiter *breachvip.RateLimiter, retries int, dedupeKey string, maxPages int, format string) bulkTermResult {
+	start := time.Now()
+	result := bulkTermResult{Term: term.Term}
+
+	req := baseReq
+	req.Term = term.Term
+	if term.Field != "" {
+		req.Fields = splitAndTrim(term.Field)
+	}
+
+	shardName := sanitizeTerm(term.Term) + formatExtensions[format]
+	writer, err := output.New(format, filepath.Join(outDir, shardName))
+	if err != nil {
+		result.Error = err.Error()
+		result.Elapsed = time.Since(start).String()
+		return result
+	}
+
+	total, emails, passwords, err := fetchAllPages(backend, req, maxPages, dedupeKey, writer, limiter, retries)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	result.Elapsed = time.Since(start).String()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Total = total
+	result.Emails = len(emails)
+	result.Passwords = len(passwords)
+
+	var sidecarErrs []string
+	if len(emails) > 0 {
+		if err := os.WriteFile(filepath.Join(outDir, emailPrefix+shardName), []byte(strings.Join(emails, "\n")), 0644); err != nil {
+			sidecarErrs = append(sidecarErrs, fmt.Sprintf("writing email sidecar: %s", err))
+		}
+	}
+	if len(passwords) > 0 {
+		if err := os.WriteFile(filepath.Join(outDir, passPrefix+shardName), []byte(strings.Join(passwords, "\n")), 0644); err != nil {
+			sidecarErrs = append(sidecarErrs, fmt.Sprintf("writing password sidecar: %s", err))
+		}
+	}
+	if len(sidecarErrs) > 0 {
+		result.Error = strings.Join(sidecarErrs, "; ")
+	}
+	return result
+}
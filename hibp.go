@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+)
+
+// hibpReportEntry is one merged HIBP result for a candidate credential.
+type hibpReportEntry struct {
+	Value string `json:"value"`
+	Field string `json:"field"`
+	Count int    `json:"count"`
+	Error string `json:"error,omitempty"`
+}
+
+// runHIBPRange checks every line of the input file (a password or email per
+// line, such as the passwords_/emails_ sidecar produced by search mode)
+// against the HIBP k-anonymity range API and writes a merged report with a
+// per-record exposure count.
+func runHIBPRange(field, inputFile, apiKey, reportOut, searchOut string) error {
+	switch field {
+	case "password", "email":
+	default:
+		return fmt.Errorf("--hibp-field must be password or email")
+	}
+	if field == "email" && apiKey == "" {
+		return fmt.Errorf("--hibp-api-key is required when --hibp-field is email")
+	}
+
+	if inputFile == "" {
+		if field == "password" {
+			inputFile = passPrefix + searchOut
+		} else {
+			inputFile = emailPrefix + searchOut
+		}
+	}
+
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", inputFile, err)
+	}
+
+	client := breachvip.NewHIBPClient(breachvip.WithHIBPAPIKey(apiKey))
+	ctx := context.Background()
+
+	var entries []hibpReportEntry
+	for _, line := range strings.Split(string(data), "\n") {
+		value := strings.TrimSpace(line)
+		if value == "" {
+			continue
+		}
+
+		var count int
+		var checkErr error
+		if field == "password" {
+			count, checkErr = client.CheckPassword(ctx, value)
+		} else {
+			count, checkErr = client.CheckEmail(ctx, value)
+		}
+
+		entry := hibpReportEntry{Value: value, Field: field}
+		if checkErr != nil {
+			entry.Error = checkErr.Error()
+		} else {
+			entry.Count = count
+		}
+		entries = append(entries, entry)
+	}
+
+	report, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling report: %w", err)
+	}
+	if err := os.WriteFile(reportOut, report, 0644); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	exposed := 0
+	for _, e := range entries {
+		if e.Count > 0 {
+			exposed++
+		}
+	}
+	fmt.Printf("Checked %d %ss against HIBP, %d exposed\n", len(entries), field, exposed)
+	fmt.Printf("Report saved to %s\n", reportOut)
+	return nil
+}
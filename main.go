@@ -1,28 +1,22 @@
 package main
 
 import (
-	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"strings"
-)
 
-type SearchRequest struct {
-	Term          string   `json:"term"`
-	Fields        []string `json:"fields"`
-	Categories    []string `json:"categories,omitempty"`
-	Wildcard      *bool    `json:"wildcard,omitempty"`
-	CaseSensitive *bool    `json:"case_sensitive,omitempty"`
-}
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+	"github.com/Mortimus/BreachLookup/pkg/breachvip/output"
+)
 
-const apiURL = "https://breach.vip/api/search"
-const maxResults = 10000
 const passPrefix = "passwords_"
 const emailPrefix = "emails_"
+const defaultPageSize = 1000
 
 func main() {
 	// CLI flags
@@ -31,27 +25,82 @@ func main() {
 	categories := flag.String("categories", "", "Comma-separated categories (optional)") // What is the category? example shows "minecraft"
 	wildcard := flag.Bool("wildcard", false, "Enable wildcard (optional)")
 	caseSensitive := flag.Bool("case", false, "Case sensitive search (optional)")
-	url := flag.String("url", apiURL, "API endpoint URL")
+	url := flag.String("url", breachvip.DefaultBaseURL, "API endpoint URL")
 	out := flag.String("out", "output.json", "Output file path")
+	pageSize := flag.Int("page-size", defaultPageSize, "Number of records requested per page")
+	maxPages := flag.Int("max-pages", 0, "Maximum number of pages to fetch (0 = no limit)")
+	resumeCursor := flag.String("resume-cursor", "", "Cursor to resume a previous run from (optional)")
+	dedupeKey := flag.String("dedupe-key", "email", "Field used to detect duplicate records across pages: email or record (full record SHA-256)")
+	mode := flag.String("mode", "search", "Operation mode: search or hibp-range")
+	hibpField := flag.String("hibp-field", "password", "Field to check in hibp-range mode: password or email")
+	hibpInput := flag.String("hibp-input", "", "Newline-delimited input file for hibp-range mode (defaults to the password/email sidecar of --out)")
+	hibpAPIKey := flag.String("hibp-api-key", "", "HIBP API key, required for --hibp-field email")
+	hibpOut := flag.String("hibp-out", "hibp_report.json", "Report output path for hibp-range mode")
+	inputFile := flag.String("input-file", "", "Newline-delimited terms (or CSV term,field) to process in bulk")
+	outDir := flag.String("out-dir", "out", "Output directory for --input-file bulk runs")
+	concurrency := flag.Int("concurrency", 4, "Number of terms to process concurrently in bulk mode")
+	rps := flag.Float64("rps", 5, "Maximum requests per second sent to the API")
+	retries := flag.Int("retries", 3, "Retries on HTTP 429/5xx before giving up on a page")
+	format := flag.String("format", "json", "Output format: json, ndjson, csv, or sqlite")
+	configPath := flag.String("config", "", "Path to a YAML/TOML config file with named search endpoints")
+	backendName := flag.String("backend", "default", "Named endpoint from --config to search with")
 	flag.Parse()
 
-	if *term == "" || *fields == "" {
-		fmt.Println("Error: --term and --fields are required.")
+	explicitFlags := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { explicitFlags[f.Name] = true })
+
+	if _, ok := output.KnownFormats[*format]; !ok {
+		fmt.Println("Error: --format must be one of json, ndjson, csv, sqlite.")
+		os.Exit(1)
+	}
+
+	if *mode == "hibp-range" {
+		if err := runHIBPRange(*hibpField, *hibpInput, *hibpAPIKey, *hibpOut, *out); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *fields == "" {
+		fmt.Println("Error: --fields is required.")
 		flag.Usage()
 		os.Exit(1)
 	}
 
 	// Validate fields
 	fieldArray := splitAndTrim(*fields)
-	if err := verifyFields(fieldArray...); err != nil {
+	if err := breachvip.VerifyFields(fieldArray...); err != nil {
 		fmt.Println("Error:", err)
 		os.Exit(1)
 	}
 
-	// Prepare request
-	req := SearchRequest{
-		Term:   *term,
-		Fields: fieldArray,
+	if *pageSize <= 0 {
+		fmt.Println("Error: --page-size must be greater than 0.")
+		os.Exit(1)
+	}
+
+	switch *dedupeKey {
+	case "email", "record":
+	default:
+		fmt.Println("Error: --dedupe-key must be one of email, record.")
+		os.Exit(1)
+	}
+
+	if *retries < 0 {
+		fmt.Println("Error: --retries must be 0 or greater.")
+		os.Exit(1)
+	}
+	if *concurrency <= 0 {
+		fmt.Println("Error: --concurrency must be greater than 0.")
+		os.Exit(1)
+	}
+
+	// Prepare request template
+	req := breachvip.SearchRequest{
+		Fields:   fieldArray,
+		PageSize: *pageSize,
+		Cursor:   *resumeCursor,
 	}
 	if *categories != "" {
 		req.Categories = splitAndTrim(*categories)
@@ -59,46 +108,60 @@ func main() {
 	req.Wildcard = wildcard
 	req.CaseSensitive = caseSensitive
 
-	body, err := json.Marshal(req)
+	backend, endpoint, err := resolveBackend(*configPath, *backendName, *url, explicitFlags["url"])
 	if err != nil {
-		fmt.Println("Error marshaling request:", err)
+		fmt.Println("Error:", err)
 		os.Exit(1)
 	}
-	fmt.Printf("Searching for %s on %s\n", *term, *url)
-	// Send POST request
-	resp, err := http.Post(*url, "application/json", bytes.NewBuffer(body))
-	if err != nil {
-		fmt.Println("Error sending request:", err)
-		os.Exit(1)
+	if endpoint != nil {
+		req.Categories = endpoint.MapCategories(req.Categories)
+	}
+	// rps <= 0 means "don't throttle": a *RateLimiter with a non-positive
+	// refill rate would spin forever waiting for a token that never
+	// accrues, so treat it the same as the nil limiter fetchPageWithRetry
+	// already skips.
+	var limiter *breachvip.RateLimiter
+	if *rps > 0 {
+		limiter = breachvip.NewRateLimiter(*rps)
 	}
-	defer resp.Body.Close()
 
-	// Check Response Status
-	if resp.StatusCode != http.StatusOK {
-		fmt.Printf("Error: received status code %d: %s\n", resp.StatusCode, resp.Status)
-		os.Exit(1)
+	if *inputFile != "" {
+		if err := runBulkMode(backend, *inputFile, req, *outDir, *concurrency, limiter, *retries, *dedupeKey, *maxPages, *format); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		fmt.Println("Error reading response:", err)
+	if *term == "" {
+		fmt.Println("Error: --term is required.")
+		flag.Usage()
 		os.Exit(1)
 	}
+	req.Term = *term
 
-	// Save to file
-	err = os.WriteFile(*out, respBody, 0644)
+	writer, err := output.New(*format, *out)
 	if err != nil {
-		fmt.Println("Error writing output file:", err)
+		fmt.Println("Error creating output file:", err)
 		os.Exit(1)
 	}
-	fmt.Printf("Response saved to %s\n", *out)
 
-	// Calculate results
-	total, emails, passwords, err := parseResults(*out)
+	searchURL := *url
+	if endpoint != nil {
+		searchURL = endpoint.URL
+	}
+	fmt.Printf("Searching for %s on %s\n", *term, searchURL)
+
+	total, emails, passwords, err := fetchAllPages(backend, req, *maxPages, *dedupeKey, writer, limiter, *retries)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
-		fmt.Println("Error parsing results:", err)
+		fmt.Println("Error fetching results:", err)
 		os.Exit(1)
 	}
+	fmt.Printf("Response saved to %s\n", *out)
+
 	// Save passwords and emails to files using prefixes
 	if len(emails) > 0 {
 		emailFile := emailPrefix + *out
@@ -120,90 +183,108 @@ func main() {
 	}
 	fmt.Printf("Emails: %d\n", len(emails))
 	fmt.Printf("Passwords: %d\n", len(passwords))
-	fmt.Printf("Total results: %d (Maximum: %d)\n", total, maxResults)
+	fmt.Printf("Total results: %d\n", total)
 }
 
-func splitAndTrim(s string) []string {
-	var result []string
-	for _, v := range bytes.Split([]byte(s), []byte{','}) {
-		str := string(bytes.TrimSpace(v))
-		if str != "" {
-			result = append(result, strings.ToLower(str))
-		}
-	}
-	return result
-}
+// fetchAllPages walks the API page by page (or cursor by cursor) via backend,
+// streaming each page's records into writer so the full response never has
+// to be buffered in memory. Records already seen under dedupeKey are
+// dropped before being written or counted. limiter (if non-nil) paces
+// requests and each page is retried up to retries times with backoff on a
+// retryable error. It returns the total number of unique records written
+// along with their emails and passwords.
+func fetchAllPages(backend breachvip.Backend, req breachvip.SearchRequest, maxPages int, dedupeKey string, writer output.Writer, limiter *breachvip.RateLimiter, retries int) (total int, emails []string, passwords []string, err error) {
+	seen := make(map[string]struct{})
+	page := 1
+	cursor := req.Cursor
+	ctx := context.Background()
 
-func verifyFields(fields ...string) error {
-	// "email" "password" "domain" "username" "ip" "name" "uuid" "steamid" "phone" "discordid"
-	validFields := map[string]struct{}{
-		"email":     {},
-		"password":  {},
-		"domain":    {},
-		"username":  {},
-		"ip":        {},
-		"name":      {},
-		"uuid":      {},
-		"steamid":   {},
-		"phone":     {},
-		"discordid": {},
-	}
-
-	for _, field := range fields {
-		if _, ok := validFields[field]; !ok {
-			return fmt.Errorf("invalid field: %s", field)
+	for {
+		if maxPages > 0 && page > maxPages {
+			break
 		}
-	}
-	return nil
-}
 
-// parseResults reads the resulting json file and returns results, email, and password counts
-func parseResults(filename string) (total int, emails []string, passwords []string, err error) {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return 0, nil, nil, err
-	}
-	// Try to parse as {"results": [...]}
-	var obj map[string]interface{}
-	if err := json.Unmarshal(data, &obj); err != nil {
-		return 0, nil, nil, err
-	}
-	arr, ok := obj["results"].([]interface{})
-	if !ok {
-		return 0, nil, nil, fmt.Errorf("results field not found or not array")
-	}
-	total = len(arr)
-	for _, item := range arr {
-		m, ok := item.(map[string]interface{})
-		if !ok {
-			continue
+		pageReq := req
+		pageReq.Page = page
+		pageReq.Cursor = cursor
+
+		resp, err := fetchPageWithRetry(ctx, backend, pageReq, limiter, retries)
+		if err != nil {
+			return total, emails, passwords, err
 		}
-		// Email field
-		if val, ok := m["email"]; ok {
-			switch v := val.(type) {
-			case string:
-				emails = append(emails, v)
-			case []interface{}:
-				for _, e := range v {
-					if es, ok := e.(string); ok {
-						emails = append(emails, es)
-					}
-				}
-			}
+		if resp == nil || len(resp.Results) == 0 {
+			break
 		}
-		// Password field
-		if val, ok := m["password"]; ok {
-			switch v := val.(type) {
-			case string:
-				passwords = append(passwords, v)
-			case []interface{}:
-				for _, p := range v {
-					if ps, ok := p.(string); ok {
-						passwords = append(passwords, ps)
-					}
+
+		newRecords := 0
+		for _, record := range resp.Results {
+			key := dedupeKeyFor(record, dedupeKey)
+			if key != "" {
+				if _, dup := seen[key]; dup {
+					continue
 				}
+				seen[key] = struct{}{}
 			}
+			newRecords++
+
+			if err := writer.Write(record); err != nil {
+				return total, emails, passwords, err
+			}
+			total++
+			emails = append(emails, []string(record.Email)...)
+			passwords = append(passwords, []string(record.Password)...)
+		}
+
+		if resp.NextCursor != "" {
+			cursor = resp.NextCursor
+			page++
+			continue
 		}
+
+		if newRecords == 0 {
+			// Every record on this cursor-less page was already seen: a
+			// misbehaving page/offset backend that ignores Page and keeps
+			// re-returning the same page would otherwise loop forever.
+			break
+		}
+		if pageReq.PageSize > 0 && len(resp.Results) == pageReq.PageSize {
+			// Cursor-less (page/offset) backend: a full page suggests more
+			// results may follow, so keep paging by Page alone.
+			page++
+			continue
+		}
+		break
 	}
+
 	return total, emails, passwords, nil
 }
+
+// dedupeKeyFor computes the value used to detect a duplicate record between
+// pages. "email" dedupes on that single field (first value when the field is
+// multi-valued); "record" falls back to the SHA-256 of the full record so
+// duplicates are still caught when no stable field is present.
+func dedupeKeyFor(record breachvip.Record, dedupeKey string) string {
+	if dedupeKey == "email" {
+		if len(record.Email) == 0 {
+			return ""
+		}
+		return "email:" + record.Email[0]
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "hash:" + hex.EncodeToString(sum[:])
+}
+
+func splitAndTrim(s string) []string {
+	var result []string
+	for _, v := range strings.Split(s, ",") {
+		str := strings.TrimSpace(v)
+		if str != "" {
+			result = append(result, strings.ToLower(str))
+		}
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package breachvip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Backend is a pluggable search API. Its Search method matches Client's, so
+// breach.vip (via Client) is just the default implementation; callers can
+// register others under their own name, e.g. a self-hosted DeHashed-like
+// API with a different wire format.
+type Backend interface {
+	Search(ctx context.Context, req SearchRequest) (*SearchResponse, error)
+}
+
+// BackendFactory builds a Backend from one endpoint's config.
+type BackendFactory func(EndpointConfig) (Backend, error)
+
+var backendFactories = map[string]BackendFactory{
+	"http": func(cfg EndpointConfig) (Backend, error) { return NewClientFromEndpoint(cfg) },
+}
+
+// RegisterBackend makes a named Backend implementation available to
+// NewBackend.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendFactories[name] = factory
+}
+
+// NewBackend builds the Backend registered under cfg.Backend (default
+// "http").
+func NewBackend(cfg EndpointConfig) (Backend, error) {
+	name := cfg.Backend
+	if name == "" {
+		name = "http"
+	}
+	factory, ok := backendFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown backend: %s", name)
+	}
+	return factory(cfg)
+}
+
+// NewClientFromEndpoint builds a Client wired up with cfg's URL, auth
+// header, and proxy settings.
+func NewClientFromEndpoint(cfg EndpointConfig) (*Client, error) {
+	httpClient := http.DefaultClient
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy url: %w", err)
+		}
+		httpClient = &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+	}
+
+	opts := []Option{WithBaseURL(cfg.URL), WithHTTPClient(httpClient)}
+	if cfg.APIKey != "" {
+		header := cfg.AuthHeader
+		if header == "" {
+			header = "Authorization"
+		}
+		opts = append(opts, WithAuthHeader(header, cfg.AuthHeaderValue()))
+	}
+	return NewClient(opts...), nil
+}
@@ -0,0 +1,57 @@
+// Package breachvip is a client library for the breach.vip lookup API. It
+// wraps the HTTP request/response cycle with typed structs so downstream Go
+// tools can search breach data without reimplementing the wire format.
+package breachvip
+
+import "net/http"
+
+// DefaultBaseURL is the breach.vip search endpoint used when no base URL is
+// configured via WithBaseURL.
+const DefaultBaseURL = "https://breach.vip/api/search"
+
+// Client talks to a breach lookup API. Use NewClient to construct one. It
+// satisfies Backend, making breach.vip (or any compatible self-hosted
+// mirror reachable with the same wire format) the default implementation.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	authHeader string
+	authValue  string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL overrides the API endpoint the Client sends searches to.
+func WithBaseURL(url string) Option {
+	return func(c *Client) { c.baseURL = url }
+}
+
+// WithHTTPClient overrides the http.Client used for requests, e.g. to set a
+// timeout or route traffic through a proxy.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithAuthHeader sets a header sent with every search request, for APIs
+// that require an API key or session cookie (e.g. "Authorization: Bearer
+// ...", or a self-hosted mirror's own header name).
+func WithAuthHeader(header, value string) Option {
+	return func(c *Client) {
+		c.authHeader = header
+		c.authValue = value
+	}
+}
+
+// NewClient builds a Client with DefaultBaseURL and http.DefaultClient unless
+// overridden by opts.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:    DefaultBaseURL,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
@@ -0,0 +1,93 @@
+package breachvip
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the top-level shape of a --config file: a set of named search
+// endpoints a caller can pick between with --backend.
+type Config struct {
+	Endpoints map[string]EndpointConfig `yaml:"endpoints" toml:"endpoints"`
+}
+
+// EndpointConfig describes one named search API: where it lives, how to
+// authenticate to it, how its categories map onto the caller's, and how to
+// route traffic to it.
+type EndpointConfig struct {
+	// Backend selects the registered Backend implementation this endpoint
+	// uses. Defaults to "http", the generic JSON-over-HTTP client that
+	// covers breach.vip and compatible self-hosted mirrors.
+	Backend string `yaml:"backend" toml:"backend"`
+	URL     string `yaml:"url" toml:"url"`
+
+	// AuthHeader is the HTTP header carrying credentials (default
+	// "Authorization"). AuthTemplate renders APIKey into that header value,
+	// e.g. "Bearer {key}"; with no template the raw key is sent.
+	AuthHeader   string `yaml:"auth_header" toml:"auth_header"`
+	AuthTemplate string `yaml:"auth_template" toml:"auth_template"`
+	APIKey       string `yaml:"api_key" toml:"api_key"`
+
+	// Categories maps a category name as the caller knows it to the name
+	// this endpoint expects.
+	Categories map[string]string `yaml:"category_mapping" toml:"category_mapping"`
+
+	// Proxy is an HTTP(S) or SOCKS5 proxy URL requests to this endpoint are
+	// routed through.
+	Proxy string `yaml:"proxy" toml:"proxy"`
+}
+
+// AuthHeaderValue renders AuthTemplate with "{key}" substituted for APIKey,
+// or returns APIKey unchanged if no template is set.
+func (e EndpointConfig) AuthHeaderValue() string {
+	if e.AuthTemplate == "" {
+		return e.APIKey
+	}
+	return strings.ReplaceAll(e.AuthTemplate, "{key}", e.APIKey)
+}
+
+// MapCategories translates categories through e.Categories, leaving any
+// category with no mapping entry unchanged.
+func (e EndpointConfig) MapCategories(categories []string) []string {
+	if len(e.Categories) == 0 || len(categories) == 0 {
+		return categories
+	}
+	mapped := make([]string, len(categories))
+	for i, category := range categories {
+		if m, ok := e.Categories[category]; ok {
+			mapped[i] = m
+		} else {
+			mapped[i] = category
+		}
+	}
+	return mapped
+}
+
+// LoadConfig reads a YAML (.yaml/.yml) or TOML (.toml) config file
+// describing one or more named endpoints.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	var cfg Config
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing yaml config: %w", err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing toml config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config extension %q (use .yaml, .yml, or .toml)", ext)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,135 @@
+package breachvip
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultHIBPPasswordRangeURL is the Have I Been Pwned k-anonymity endpoint
+// for password hashes. Only the first 5 hex chars of the SHA-1 hash are ever
+// sent to it.
+const DefaultHIBPPasswordRangeURL = "https://api.pwnedpasswords.com/range/"
+
+// DefaultHIBPEmailRangeURL is the equivalent k-anonymity endpoint for email
+// addresses, keyed by SHA-256 prefix. It requires an API key.
+const DefaultHIBPEmailRangeURL = "https://api.haveibeenpwned.com/range/email/"
+
+// HIBPClient checks candidate passwords and emails against a k-anonymity
+// range API without ever sending the full credential over the wire.
+type HIBPClient struct {
+	httpClient       *http.Client
+	passwordRangeURL string
+	emailRangeURL    string
+	apiKey           string
+}
+
+// HIBPOption configures a HIBPClient.
+type HIBPOption func(*HIBPClient)
+
+// WithHIBPAPIKey sets the API key sent with email range lookups.
+func WithHIBPAPIKey(key string) HIBPOption {
+	return func(c *HIBPClient) { c.apiKey = key }
+}
+
+// WithHIBPHTTPClient overrides the http.Client used for requests.
+func WithHIBPHTTPClient(hc *http.Client) HIBPOption {
+	return func(c *HIBPClient) { c.httpClient = hc }
+}
+
+// WithHIBPBaseURLs overrides the password and email range endpoints, mainly
+// for pointing tests or self-hosted mirrors at a different host.
+func WithHIBPBaseURLs(passwordRangeURL, emailRangeURL string) HIBPOption {
+	return func(c *HIBPClient) {
+		c.passwordRangeURL = passwordRangeURL
+		c.emailRangeURL = emailRangeURL
+	}
+}
+
+// NewHIBPClient builds a HIBPClient with the default range endpoints unless
+// overridden by opts.
+func NewHIBPClient(opts ...HIBPOption) *HIBPClient {
+	c := &HIBPClient{
+		httpClient:       http.DefaultClient,
+		passwordRangeURL: DefaultHIBPPasswordRangeURL,
+		emailRangeURL:    DefaultHIBPEmailRangeURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CheckPassword returns how many times password appears in HIBP's breach
+// corpus. Only the first 5 hex characters of its SHA-1 hash are sent.
+func (c *HIBPClient) CheckPassword(ctx context.Context, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return c.lookupRange(ctx, c.passwordRangeURL, hash, false)
+}
+
+// CheckEmail returns how many times email appears in HIBP's breach corpus.
+// Only the first 5 hex characters of its SHA-256 hash are sent. Requires an
+// API key set via WithHIBPAPIKey.
+func (c *HIBPClient) CheckEmail(ctx context.Context, email string) (int, error) {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return c.lookupRange(ctx, c.emailRangeURL, hash, true)
+}
+
+// lookupRange sends the hash prefix to baseURL and scans the returned
+// "SUFFIX:COUNT" lines for a match against the full hash.
+func (c *HIBPClient) lookupRange(ctx context.Context, baseURL, fullHash string, requiresAPIKey bool) (int, error) {
+	if requiresAPIKey && c.apiKey == "" {
+		return 0, fmt.Errorf("hibp: API key required for this lookup")
+	}
+	prefix, suffix := fullHash[:5], fullHash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+prefix, nil)
+	if err != nil {
+		return 0, fmt.Errorf("building request: %w", err)
+	}
+	if requiresAPIKey {
+		req.Header.Set("hibp-api-key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("received status code %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	count, err := scanRangeResponse(resp.Body, suffix)
+	if err != nil {
+		return 0, fmt.Errorf("reading response: %w", err)
+	}
+	return count, nil
+}
+
+// scanRangeResponse reads "SUFFIX:COUNT" lines and returns the count for
+// suffix, or 0 if it isn't present.
+func scanRangeResponse(body io.Reader, suffix string) (int, error) {
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if parts[0] == suffix {
+			return strconv.Atoi(strings.TrimSpace(parts[1]))
+		}
+	}
+	return 0, scanner.Err()
+}
@@ -0,0 +1,27 @@
+package breachvip
+
+// Emails returns every email value present across all results in the
+// response.
+func (r *SearchResponse) Emails() []string {
+	return r.fieldValues(func(rec Record) StringOrSlice { return rec.Email })
+}
+
+// Passwords returns every password value present across all results in the
+// response.
+func (r *SearchResponse) Passwords() []string {
+	return r.fieldValues(func(rec Record) StringOrSlice { return rec.Password })
+}
+
+// Domains returns every domain value present across all results in the
+// response.
+func (r *SearchResponse) Domains() []string {
+	return r.fieldValues(func(rec Record) StringOrSlice { return rec.Domain })
+}
+
+func (r *SearchResponse) fieldValues(get func(Record) StringOrSlice) []string {
+	var out []string
+	for _, rec := range r.Results {
+		out = append(out, get(rec)...)
+	}
+	return out
+}
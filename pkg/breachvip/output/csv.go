@@ -0,0 +1,61 @@
+package output
+
+import (
+	"encoding/csv"
+	"os"
+	"strings"
+
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+)
+
+// csvColumns are the normalized columns every CSV export carries, in order.
+var csvColumns = []string{
+	"email", "password", "domain", "username", "ip",
+	"name", "uuid", "steamid", "phone", "discordid", "source",
+}
+
+// csvWriter emits one normalized row per record. Multi-valued fields are
+// joined with ";".
+type csvWriter struct {
+	f *os.File
+	w *csv.Writer
+}
+
+func newCSVWriter(path string) (Writer, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	w := csv.NewWriter(f)
+	if err := w.Write(csvColumns); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &csvWriter{f: f, w: w}, nil
+}
+
+func (c *csvWriter) Write(record breachvip.Record) error {
+	row := []string{
+		strings.Join(record.Email, ";"),
+		strings.Join(record.Password, ";"),
+		strings.Join(record.Domain, ";"),
+		strings.Join(record.Username, ";"),
+		strings.Join(record.IP, ";"),
+		strings.Join(record.Name, ";"),
+		strings.Join(record.UUID, ";"),
+		strings.Join(record.SteamID, ";"),
+		strings.Join(record.Phone, ";"),
+		strings.Join(record.DiscordID, ";"),
+		strings.Join(record.Source, ";"),
+	}
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		c.f.Close()
+		return err
+	}
+	return c.f.Close()
+}
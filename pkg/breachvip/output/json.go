@@ -0,0 +1,50 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+)
+
+// jsonWriter streams records into a single `{"results":[...]}` JSON document
+// without buffering the full array in memory.
+type jsonWriter struct {
+	f     *os.File
+	enc   *json.Encoder
+	wrote bool
+}
+
+func newJSONWriter(path string) (Writer, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(f, `{"results":[`); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &jsonWriter{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+func (w *jsonWriter) Write(record breachvip.Record) error {
+	if w.wrote {
+		if _, err := io.WriteString(w.f, ","); err != nil {
+			return err
+		}
+	}
+	if err := w.enc.Encode(record); err != nil {
+		return err
+	}
+	w.wrote = true
+	return nil
+}
+
+func (w *jsonWriter) Close() error {
+	if _, err := io.WriteString(w.f, "]}"); err != nil {
+		w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
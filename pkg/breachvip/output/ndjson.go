@@ -0,0 +1,31 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+)
+
+// ndjsonWriter writes one JSON object per line, suited to jq/stream
+// tooling.
+type ndjsonWriter struct {
+	f   *os.File
+	enc *json.Encoder
+}
+
+func newNDJSONWriter(path string) (Writer, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ndjsonWriter{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+func (w *ndjsonWriter) Write(record breachvip.Record) error {
+	return w.enc.Encode(record)
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.f.Close()
+}
@@ -0,0 +1,43 @@
+// Package output streams breach.vip search results to disk in a chosen
+// format. Each format implements Writer so the caller can write records one
+// at a time without buffering the full result set in memory.
+package output
+
+import (
+	"fmt"
+
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+)
+
+// Writer streams search results to a destination in a specific format.
+// Write may be called any number of times; Close flushes and finalizes the
+// destination and must be called exactly once when writing is done.
+type Writer interface {
+	Write(record breachvip.Record) error
+	Close() error
+}
+
+// KnownFormats are the --format values New accepts.
+var KnownFormats = map[string]struct{}{
+	"json":   {},
+	"ndjson": {},
+	"csv":    {},
+	"sqlite": {},
+}
+
+// New opens path and returns a Writer for format. The caller must Close the
+// returned Writer.
+func New(format, path string) (Writer, error) {
+	switch format {
+	case "json":
+		return newJSONWriter(path)
+	case "ndjson":
+		return newNDJSONWriter(path)
+	case "csv":
+		return newCSVWriter(path)
+	case "sqlite":
+		return newSQLiteWriter(path)
+	default:
+		return nil, fmt.Errorf("unknown output format: %s", format)
+	}
+}
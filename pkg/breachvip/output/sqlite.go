@@ -0,0 +1,106 @@
+package output
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS results (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	email TEXT,
+	password TEXT,
+	domain TEXT,
+	username TEXT,
+	ip TEXT,
+	name TEXT,
+	uuid TEXT,
+	steamid TEXT,
+	phone TEXT,
+	discordid TEXT,
+	source TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_results_email ON results(email);
+CREATE INDEX IF NOT EXISTS idx_results_domain ON results(domain);
+
+CREATE TABLE IF NOT EXISTS emails (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	result_id INTEGER NOT NULL REFERENCES results(id),
+	email TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_emails_email ON emails(email);
+
+CREATE TABLE IF NOT EXISTS passwords (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	result_id INTEGER NOT NULL REFERENCES results(id),
+	password TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_passwords_password ON passwords(password);
+`
+
+// sqliteWriter persists records into a SQLite database via modernc.org/sqlite
+// (pure Go, no CGO). A result row is kept alongside one row per value in the
+// emails/passwords tables so either can be queried directly and indexed.
+type sqliteWriter struct {
+	db *sql.DB
+}
+
+func newSQLiteWriter(path string) (Writer, error) {
+	// modernc.org/sqlite doesn't truncate an existing file on open; start
+	// fresh like the other formats do via os.Create.
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("removing existing database: %w", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating schema: %w", err)
+	}
+	return &sqliteWriter{db: db}, nil
+}
+
+func (w *sqliteWriter) Write(record breachvip.Record) error {
+	res, err := w.db.Exec(
+		`INSERT INTO results (email, password, domain, username, ip, name, uuid, steamid, phone, discordid, source)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		strings.Join(record.Email, ";"), strings.Join(record.Password, ";"), strings.Join(record.Domain, ";"),
+		strings.Join(record.Username, ";"), strings.Join(record.IP, ";"), strings.Join(record.Name, ";"),
+		strings.Join(record.UUID, ";"), strings.Join(record.SteamID, ";"), strings.Join(record.Phone, ";"),
+		strings.Join(record.DiscordID, ";"), strings.Join(record.Source, ";"),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting result: %w", err)
+	}
+	resultID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("reading inserted id: %w", err)
+	}
+
+	for _, email := range record.Email {
+		if _, err := w.db.Exec(`INSERT INTO emails (result_id, email) VALUES (?, ?)`, resultID, email); err != nil {
+			return fmt.Errorf("inserting email: %w", err)
+		}
+	}
+	for _, password := range record.Password {
+		if _, err := w.db.Exec(`INSERT INTO passwords (result_id, password) VALUES (?, ?)`, resultID, password); err != nil {
+			return fmt.Errorf("inserting password: %w", err)
+		}
+	}
+	return nil
+}
+
+func (w *sqliteWriter) Close() error {
+	return w.db.Close()
+}
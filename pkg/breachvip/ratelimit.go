@@ -0,0 +1,55 @@
+package breachvip
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token-bucket limiter used to cap outbound request rate
+// against upstream APIs that enforce a requests-per-second quota.
+type RateLimiter struct {
+	mu         sync.Mutex
+	tokens     float64
+	max        float64
+	refillRate float64 // tokens per second
+	last       time.Time
+}
+
+// NewRateLimiter builds a RateLimiter allowing up to rps requests per
+// second, with a burst capacity of rps tokens (at least 1, so rates below
+// one request per second still let a request through).
+func NewRateLimiter(rps float64) *RateLimiter {
+	burst := max(rps, 1)
+	return &RateLimiter{
+		tokens:     burst,
+		max:        burst,
+		refillRate: rps,
+		last:       time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		now := time.Now()
+		r.tokens = min(r.max, r.tokens+now.Sub(r.last).Seconds()*r.refillRate)
+		r.last = now
+		if r.tokens >= 1 {
+			r.tokens--
+			r.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - r.tokens) / r.refillRate * float64(time.Second))
+		r.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
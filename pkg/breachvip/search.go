@@ -0,0 +1,80 @@
+package breachvip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIError reports a non-200 response from the API, so callers can decide
+// whether a status code (e.g. 429 or 5xx) is worth retrying.
+type APIError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("received status code %d: %s", e.StatusCode, e.Status)
+}
+
+// SearchRequest describes one page of a breach.vip search.
+type SearchRequest struct {
+	Term          string   `json:"term"`
+	Fields        []string `json:"fields"`
+	Categories    []string `json:"categories,omitempty"`
+	Wildcard      *bool    `json:"wildcard,omitempty"`
+	CaseSensitive *bool    `json:"case_sensitive,omitempty"`
+	Page          int      `json:"page,omitempty"`
+	PageSize      int      `json:"page_size,omitempty"`
+	Cursor        string   `json:"cursor,omitempty"`
+}
+
+// SearchResponse is one page of search results. NextCursor is empty once the
+// last page has been returned.
+type SearchResponse struct {
+	Results    []Record `json:"results"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+// Search sends a single page of req to the API and returns the typed
+// response. Callers that expect more results than fit on one page should
+// follow SearchResponse.NextCursor into req.Cursor and call Search again.
+func (c *Client) Search(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.authHeader != "" {
+		httpReq.Header.Set(c.authHeader, c.authValue)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	var parsed SearchResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return &parsed, nil
+}
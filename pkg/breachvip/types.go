@@ -0,0 +1,137 @@
+package breachvip
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ValidFields are the record fields the API accepts in a SearchRequest.
+var ValidFields = map[string]struct{}{
+	"email":     {},
+	"password":  {},
+	"domain":    {},
+	"username":  {},
+	"ip":        {},
+	"name":      {},
+	"uuid":      {},
+	"steamid":   {},
+	"phone":     {},
+	"discordid": {},
+}
+
+var knownRecordFields = map[string]struct{}{
+	"email": {}, "password": {}, "domain": {}, "username": {}, "ip": {},
+	"name": {}, "uuid": {}, "steamid": {}, "phone": {}, "discordid": {}, "source": {},
+}
+
+// StringOrSlice decodes a JSON field that the API may return as either a
+// single string or an array of strings into a uniform []string, and
+// re-encodes a single-element slice back to a bare string to match the
+// shape callers expect.
+type StringOrSlice []string
+
+func (s *StringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		if single == "" {
+			*s = nil
+		} else {
+			*s = StringOrSlice{single}
+		}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return err
+	}
+	*s = multi
+	return nil
+}
+
+func (s StringOrSlice) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
+// Record is a single breach result. Known fields are strongly typed; any
+// fields the API returns beyond those are preserved in Extra so callers
+// don't silently lose data and round-tripping through MarshalJSON keeps
+// them.
+type Record struct {
+	Email     StringOrSlice `json:"email,omitempty"`
+	Password  StringOrSlice `json:"password,omitempty"`
+	Domain    StringOrSlice `json:"domain,omitempty"`
+	Username  StringOrSlice `json:"username,omitempty"`
+	IP        StringOrSlice `json:"ip,omitempty"`
+	Name      StringOrSlice `json:"name,omitempty"`
+	UUID      StringOrSlice `json:"uuid,omitempty"`
+	SteamID   StringOrSlice `json:"steamid,omitempty"`
+	Phone     StringOrSlice `json:"phone,omitempty"`
+	DiscordID StringOrSlice `json:"discordid,omitempty"`
+	Source    StringOrSlice `json:"source,omitempty"`
+
+	Extra json.RawMessage `json:"-"`
+}
+
+func (r *Record) UnmarshalJSON(data []byte) error {
+	type alias Record
+	var a alias
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+	*r = Record(a)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for field := range knownRecordFields {
+		delete(raw, field)
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+	extra, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	r.Extra = extra
+	return nil
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	type alias Record
+	base, err := json.Marshal(alias(r))
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Extra) == 0 {
+		return base, nil
+	}
+
+	merged := map[string]json.RawMessage{}
+	if err := json.Unmarshal(base, &merged); err != nil {
+		return nil, err
+	}
+	var extra map[string]json.RawMessage
+	if err := json.Unmarshal(r.Extra, &extra); err != nil {
+		return nil, err
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return json.Marshal(merged)
+}
+
+// VerifyFields returns an error naming the first field that isn't a known
+// search field.
+func VerifyFields(fields ...string) error {
+	for _, field := range fields {
+		if _, ok := ValidFields[field]; !ok {
+			return fmt.Errorf("invalid field: %s", field)
+		}
+	}
+	return nil
+}
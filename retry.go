@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/Mortimus/BreachLookup/pkg/breachvip"
+)
+
+const baseBackoff = 500 * time.Millisecond
+const maxBackoff = 30 * time.Second
+
+// fetchPageWithRetry waits on limiter (if set), sends one page request, and
+// retries up to retries times with exponential backoff and jitter when the
+// API responds with a retryable error (HTTP 429 or 5xx).
+func fetchPageWithRetry(ctx context.Context, backend breachvip.Backend, req breachvip.SearchRequest, limiter *breachvip.RateLimiter, retries int) (*breachvip.SearchResponse, error) {
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := backend.Search(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+
+		if !isRetryable(err) || attempt == retries {
+			return nil, err
+		}
+
+		wait := backoffWithJitter(attempt)
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+	return nil, lastErr
+}
+
+// isRetryable reports whether err is an HTTP 429 or 5xx response worth
+// retrying.
+func isRetryable(err error) bool {
+	var apiErr *breachvip.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == 429 || apiErr.StatusCode >= 500
+}
+
+// backoffWithJitter returns an exponential backoff delay for the given retry
+// attempt (0-indexed), capped at maxBackoff and jittered by up to 50% to
+// avoid synchronized retries across workers.
+func backoffWithJitter(attempt int) time.Duration {
+	delay := baseBackoff
+	for i := 0; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) / 2))
+	return delay/2 + jitter
+}